@@ -0,0 +1,32 @@
+//go:build linux
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+)
+
+// readClipboard reads the X11 clipboard selection for the {{clipboard}}
+// snippet placeholder, trying xclip then xsel. Unsupported on Wayland.
+func readClipboard() (string, error) {
+	if backend != "x11" {
+		return "", fmt.Errorf("clipboard read not supported on Wayland")
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command("xclip", "-selection", "clipboard", "-o")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err == nil {
+		return out.String(), nil
+	}
+
+	out.Reset()
+	cmd = exec.Command("xsel", "--clipboard", "--output")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("readClipboard: neither xclip nor xsel available: %w", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,45 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+
+	procGlobalLock       = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock     = kernel32.NewProc("GlobalUnlock")
+	procOpenClipboard    = user32.NewProc("OpenClipboard")
+	procCloseClipboard   = user32.NewProc("CloseClipboard")
+	procGetClipboardData = user32.NewProc("GetClipboardData")
+)
+
+const cfUnicodeText = 13
+
+// readClipboard reads the Windows clipboard's CF_UNICODETEXT contents for
+// the {{clipboard}} snippet placeholder.
+func readClipboard() (string, error) {
+	r, _, _ := procOpenClipboard.Call(0)
+	if r == 0 {
+		return "", fmt.Errorf("readClipboard: OpenClipboard failed")
+	}
+	defer procCloseClipboard.Call()
+
+	h, _, _ := procGetClipboardData.Call(cfUnicodeText)
+	if h == 0 {
+		return "", fmt.Errorf("readClipboard: clipboard has no text")
+	}
+
+	ptr, _, _ := procGlobalLock.Call(h)
+	if ptr == 0 {
+		return "", fmt.Errorf("readClipboard: GlobalLock failed")
+	}
+	defer procGlobalUnlock.Call(h)
+
+	return windows.UTF16PtrToString((*uint16)(unsafe.Pointer(ptr))), nil
+}
@@ -0,0 +1,8 @@
+package main
+
+// WindowInfo is the OS-agnostic view of a window (ID + title) shared by
+// ListWindows, TypeText and LastActiveSubscribe across platform builds.
+type WindowInfo struct {
+	ID    string
+	Title string
+}
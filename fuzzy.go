@@ -0,0 +1,173 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scoring constants for fuzzyScore's DP, tuned fzf/Sublime-style: bonuses
+// for consecutive matches and matches at a word boundary or camelCase
+// transition, and a penalty for each candidate rune skipped after the first
+// match (runes before it are free).
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyBonusConsecutive = 16
+	fuzzyBonusBoundary    = 12
+	fuzzyBonusCamel       = 12
+	fuzzyGapPenalty       = -3
+)
+
+const negInf = math.MinInt32 / 2
+
+// fuzzyResult is one fuzzy-matched candidate: its index into the slice
+// passed to fuzzyFilter, its score, and the matched rune positions.
+type fuzzyResult struct {
+	Index     int
+	Score     int
+	Positions []int
+}
+
+// fuzzyFilter scores every candidate against query, drops non-matches, and
+// returns the rest sorted by descending score. An empty query matches
+// everything in its original order.
+func fuzzyFilter(query string, candidates []string) []fuzzyResult {
+	if strings.TrimSpace(query) == "" {
+		results := make([]fuzzyResult, len(candidates))
+		for i := range candidates {
+			results[i] = fuzzyResult{Index: i}
+		}
+		return results
+	}
+
+	results := make([]fuzzyResult, 0, len(candidates))
+	for i, c := range candidates {
+		score, positions, ok := fuzzyScore(query, c)
+		if !ok {
+			continue
+		}
+		results = append(results, fuzzyResult{Index: i, Score: score, Positions: positions})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// runeClass buckets a rune for boundary-bonus purposes.
+type runeClass int
+
+const (
+	classSeparator runeClass = iota
+	classLower
+	classUpper
+	classDigit
+)
+
+func classify(r rune) runeClass {
+	switch {
+	case unicode.IsUpper(r):
+		return classUpper
+	case unicode.IsLower(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	default:
+		return classSeparator
+	}
+}
+
+// boundaryBonus scores how good position j in candidate is to start or
+// continue a match: separator and camelCase boundaries score above a match
+// mid-run.
+func boundaryBonus(candidate []rune, j int) int {
+	if j == 0 {
+		return fuzzyBonusBoundary
+	}
+	prev := classify(candidate[j-1])
+	cur := classify(candidate[j])
+	if prev == classSeparator && cur != classSeparator {
+		return fuzzyBonusBoundary
+	}
+	if prev == classLower && cur == classUpper {
+		return fuzzyBonusCamel
+	}
+	return 0
+}
+
+// fuzzyScore runs a Smith-Waterman-like DP requiring every rune of query to
+// appear in candidate, in order, case-insensitively. M[i][j] tracks the
+// best score ending with a match at candidate[j-1], for the consecutive
+// bonus.
+func fuzzyScore(query, candidate string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	orig := []rune(candidate)
+	c := []rune(strings.ToLower(candidate))
+	n, m := len(q), len(c)
+	if n == 0 {
+		return 0, nil, true
+	}
+	if m < n {
+		return 0, nil, false
+	}
+
+	H := make([][]int, n+1)
+	M := make([][]int, n+1)
+	for i := range H {
+		H[i] = make([]int, m+1)
+		M[i] = make([]int, m+1)
+		for j := range H[i] {
+			if i == 0 {
+				H[i][j] = 0
+			} else {
+				H[i][j] = negInf
+			}
+			M[i][j] = negInf
+		}
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := i; j <= m; j++ {
+			if c[j-1] == q[i-1] {
+				pred := H[i-1][j-1]
+				if M[i-1][j-1] > negInf && M[i-1][j-1]+fuzzyBonusConsecutive > pred {
+					pred = M[i-1][j-1] + fuzzyBonusConsecutive
+				}
+				if pred > negInf {
+					M[i][j] = pred + fuzzyScoreMatch + boundaryBonus(orig, j-1)
+				}
+			}
+			best := H[i][j-1] + fuzzyGapPenalty
+			if j-1 < i-1 {
+				best = negInf // not enough candidate runes left to fit the rest of the query
+			}
+			if M[i][j] > best {
+				best = M[i][j]
+			}
+			H[i][j] = best
+		}
+	}
+
+	if H[n][m] <= negInf {
+		return 0, nil, false
+	}
+
+	// Backtrack from the best-scoring end state to recover matched
+	// positions for highlighting.
+	positions := make([]int, 0, n)
+	i, j := n, m
+	for i > 0 && j > 0 {
+		if M[i][j] == H[i][j] && M[i][j] > negInf {
+			positions = append(positions, j-1)
+			i, j = i-1, j-1
+			continue
+		}
+		j--
+	}
+	for l, r := 0, len(positions)-1; l < r; l, r = l+1, r-1 {
+		positions[l], positions[r] = positions[r], positions[l]
+	}
+
+	return H[n][m], positions, true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyScoreOrderingRequired(t *testing.T) {
+	if _, _, ok := fuzzyScore("bca", "abc"); ok {
+		t.Fatal("expected out-of-order query to not match")
+	}
+	if _, _, ok := fuzzyScore("abcd", "abc"); ok {
+		t.Fatal("expected query longer than candidate to not match")
+	}
+	if _, _, ok := fuzzyScore("abc", "aXbXc"); !ok {
+		t.Fatal("expected in-order query with gaps to match")
+	}
+}
+
+func TestFuzzyScoreHighlightPositions(t *testing.T) {
+	_, positions, ok := fuzzyScore("abc", "xaXbXc")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	want := []int{1, 3, 5}
+	if !reflect.DeepEqual(positions, want) {
+		t.Fatalf("positions = %v, want %v", positions, want)
+	}
+}
+
+func TestFuzzyScoreConsecutiveBeatsScattered(t *testing.T) {
+	consecutive, _, ok := fuzzyScore("win", "xwinx")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	scattered, _, ok := fuzzyScore("win", "wxixnx")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	if consecutive <= scattered {
+		t.Fatalf("consecutive score %d should beat scattered score %d", consecutive, scattered)
+	}
+}
+
+func TestFuzzyScoreBoundaryBeatsMidWord(t *testing.T) {
+	boundary, _, ok := fuzzyScore("gc", "Go Clip")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	midWord, _, ok := fuzzyScore("gc", "xgxcx")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	if boundary <= midWord {
+		t.Fatalf("word-boundary score %d should beat mid-word score %d", boundary, midWord)
+	}
+}
+
+func TestFuzzyScoreCamelTransitionBonus(t *testing.T) {
+	camel, _, ok := fuzzyScore("gc", "goClip")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	noCamel, _, ok := fuzzyScore("gc", "goclip")
+	if !ok {
+		t.Fatal("expected match")
+	}
+	if camel <= noCamel {
+		t.Fatalf("camelCase transition score %d should beat plain score %d", camel, noCamel)
+	}
+}
+
+func TestFuzzyFilterEmptyQueryReturnsAllInOrder(t *testing.T) {
+	candidates := []string{"Charlie", "Alpha", "Bravo"}
+	results := fuzzyFilter("", candidates)
+	if len(results) != len(candidates) {
+		t.Fatalf("got %d results, want %d", len(results), len(candidates))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Fatalf("results[%d].Index = %d, want %d (original order preserved)", i, r.Index, i)
+		}
+	}
+}
+
+func TestFuzzyFilterDropsNonMatchesAndSortsByScore(t *testing.T) {
+	candidates := []string{"xyz", "Go Clipboard", "Golang Clip", "nothing"}
+	results := fuzzyFilter("gc", candidates)
+
+	for _, r := range results {
+		if candidates[r.Index] == "xyz" || candidates[r.Index] == "nothing" {
+			t.Fatalf("non-matching candidate %q should have been dropped", candidates[r.Index])
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i-1].Score < results[i].Score {
+			t.Fatalf("results not sorted by descending score: %v", results)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// inputEvent mirrors struct input_event from linux/input.h (time fields
+// collapsed to their on-the-wire size on amd64/arm64, which is all goclip
+// targets).
+type inputEvent struct {
+	Sec   int64
+	Usec  int64
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+const inputEventSize = 24 // sizeof(struct input_event) on 64-bit Linux
+
+const evKey = 0x01
+
+// evdev key codes for the modifiers and digit row a hotkey chord like
+// "Ctrl+Alt+1" can reference.
+const (
+	keyLeftCtrl   = 29
+	keyRightCtrl  = 97
+	keyLeftAlt    = 56
+	keyRightAlt   = 100
+	keyLeftShift  = 42
+	keyRightShift = 54
+)
+
+var digitKeyCodes = map[string]uint16{
+	"1": 2, "2": 3, "3": 4, "4": 5, "5": 6,
+	"6": 7, "7": 8, "8": 9, "9": 10, "0": 11,
+}
+
+// hotkeyChord is a parsed "Ctrl+Alt+1"-style binding: the modifier keycodes
+// that must be held and the base keycode that fires it.
+type hotkeyChord struct {
+	raw       string
+	modifiers map[uint16]bool
+	base      uint16
+}
+
+// parseHotkeyChord parses a chord string like "Ctrl+Alt+1". Only Ctrl, Alt
+// and Shift modifiers and the top-row digits are supported, which covers
+// every chord goclip's snippet UI offers.
+func parseHotkeyChord(chord string) (hotkeyChord, error) {
+	parts := strings.Split(chord, "+")
+	if len(parts) < 2 {
+		return hotkeyChord{}, fmt.Errorf("hotkey %q: need at least one modifier and a key", chord)
+	}
+
+	hc := hotkeyChord{raw: chord, modifiers: map[uint16]bool{}}
+	for _, p := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case "ctrl", "control":
+			hc.modifiers[keyLeftCtrl] = true
+		case "alt":
+			hc.modifiers[keyLeftAlt] = true
+		case "shift":
+			hc.modifiers[keyLeftShift] = true
+		default:
+			return hotkeyChord{}, fmt.Errorf("hotkey %q: unknown modifier %q", chord, p)
+		}
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	code, ok := digitKeyCodes[key]
+	if !ok {
+		return hotkeyChord{}, fmt.Errorf("hotkey %q: unsupported key %q (only 0-9 supported)", chord, key)
+	}
+	hc.base = code
+	return hc, nil
+}
+
+// matches reports whether held (the currently pressed keycodes) satisfies
+// this chord: every configured modifier held, and the base key among those
+// held, tolerating either left or right variant of each modifier.
+func (hc hotkeyChord) matches(held map[uint16]bool) bool {
+	if !held[hc.base] {
+		return false
+	}
+	if hc.modifiers[keyLeftCtrl] && !(held[keyLeftCtrl] || held[keyRightCtrl]) {
+		return false
+	}
+	if hc.modifiers[keyLeftAlt] && !(held[keyLeftAlt] || held[keyRightAlt]) {
+		return false
+	}
+	if hc.modifiers[keyLeftShift] && !(held[keyLeftShift] || held[keyRightShift]) {
+		return false
+	}
+	return true
+}
+
+// watchHotkeys reads raw key events from every /dev/input/eventN device it
+// can open and calls onFire(chord) each time the held keys match one of
+// chords. It needs read access to the input devices (usually the "input"
+// group), the same permission tier typeWithUinput already assumes for
+// /dev/uinput. Returns a stop func that closes every device and ends the
+// reader goroutines.
+func watchHotkeys(chords []string, onFire func(chord string)) (stop func(), err error) {
+	parsed := make([]hotkeyChord, 0, len(chords))
+	for _, c := range chords {
+		hc, err := parseHotkeyChord(c)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, hc)
+	}
+
+	devices, err := filepath.Glob("/dev/input/event*")
+	if err != nil {
+		return nil, fmt.Errorf("hotkeys: %w", err)
+	}
+
+	var (
+		mu   sync.Mutex
+		held = map[uint16]bool{}
+		fds  []*os.File
+		done = make(chan struct{})
+	)
+
+	// fire updates held for a key event and, only on the initial key-down (not
+	// release and not autorepeat), checks it against every chord. Without the
+	// autorepeat exclusion, holding a chord down re-fires onFire on every
+	// repeat event the kernel sends.
+	fire := func(code uint16, value int32) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch value {
+		case 0: // up
+			delete(held, code)
+			return
+		case 1: // down
+			held[code] = true
+		default: // autorepeat: already accounted for in held, don't re-fire
+			return
+		}
+		for _, hc := range parsed {
+			if hc.matches(held) {
+				onFire(hc.raw)
+			}
+		}
+	}
+
+	for _, path := range devices {
+		f, err := os.Open(path)
+		if err != nil {
+			continue // likely a device we don't have permission for; skip it
+		}
+		fds = append(fds, f)
+
+		go func(f *os.File) {
+			buf := make([]byte, inputEventSize)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				n, err := f.Read(buf)
+				if err != nil || n != inputEventSize {
+					return
+				}
+				ev := decodeInputEvent(buf)
+				if ev.Type != evKey {
+					continue
+				}
+				fire(ev.Code, ev.Value)
+			}
+		}(f)
+	}
+
+	if len(fds) == 0 {
+		return nil, fmt.Errorf("hotkeys: no readable /dev/input/event* devices (check input group membership)")
+	}
+
+	stop = func() {
+		close(done)
+		for _, f := range fds {
+			f.Close()
+		}
+	}
+	return stop, nil
+}
+
+func decodeInputEvent(buf []byte) inputEvent {
+	return inputEvent{
+		Sec:   int64(binary.LittleEndian.Uint64(buf[0:8])),
+		Usec:  int64(binary.LittleEndian.Uint64(buf[8:16])),
+		Type:  binary.LittleEndian.Uint16(buf[16:18]),
+		Code:  binary.LittleEndian.Uint16(buf[18:20]),
+		Value: int32(binary.LittleEndian.Uint32(buf[20:24])),
+	}
+}
@@ -0,0 +1,138 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+var (
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+	procGetCurrentThreadId = kernel32.NewProc("GetCurrentThreadId")
+)
+
+const (
+	modAltW     = 0x0001
+	modControlW = 0x0002
+	modShiftW   = 0x0004
+	wmHotkey    = 0x0312
+	wmQuitW     = 0x0012
+)
+
+// msgW mirrors MSG from winuser.h.
+type msgW struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	PtX     int32
+	PtY     int32
+}
+
+type parsedHotkey struct {
+	chord string
+	mods  uint32
+	vk    uint32
+}
+
+// parseHotkeyChord parses a chord string like "Ctrl+Alt+1" into the
+// MOD_* flags and virtual-key code RegisterHotKey expects. Only Ctrl, Alt
+// and Shift modifiers and the top-row digits are supported, which covers
+// every chord goclip's snippet UI offers.
+func parseHotkeyChord(chord string) (parsedHotkey, error) {
+	parts := strings.Split(chord, "+")
+	if len(parts) < 2 {
+		return parsedHotkey{}, fmt.Errorf("hotkey %q: need at least one modifier and a key", chord)
+	}
+
+	var mods uint32
+	for _, p := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case "ctrl", "control":
+			mods |= modControlW
+		case "alt":
+			mods |= modAltW
+		case "shift":
+			mods |= modShiftW
+		default:
+			return parsedHotkey{}, fmt.Errorf("hotkey %q: unknown modifier %q", chord, p)
+		}
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return parsedHotkey{}, fmt.Errorf("hotkey %q: unsupported key %q (only 0-9 supported)", chord, key)
+	}
+	// The digit virtual-key codes (VK_0..VK_9) equal their ASCII values.
+	return parsedHotkey{chord: chord, mods: mods, vk: uint32(key[0])}, nil
+}
+
+// watchHotkeys registers each of chords as a global hotkey via
+// RegisterHotKey and calls onFire(chord) as WM_HOTKEY messages arrive.
+// RegisterHotKey ties a hotkey to its registering thread, so the watcher
+// goroutine locks itself to one OS thread and runs its own GetMessage pump.
+func watchHotkeys(chords []string, onFire func(chord string)) (stop func(), err error) {
+	parsed := make([]parsedHotkey, len(chords))
+	for i, c := range chords {
+		p, err := parseHotkeyChord(c)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = p
+	}
+
+	ready := make(chan error, 1)
+	threadID := make(chan uint32, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		tid, _, _ := procGetCurrentThreadId.Call()
+		threadID <- uint32(tid)
+
+		for i, p := range parsed {
+			r, _, callErr := procRegisterHotKey.Call(0, uintptr(i+1), uintptr(p.mods), uintptr(p.vk))
+			if r == 0 {
+				ready <- fmt.Errorf("hotkeys: RegisterHotKey(%q): %w", p.chord, callErr)
+				return
+			}
+		}
+		ready <- nil
+
+		for {
+			var m msgW
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+			if r == 0 || int32(r) == -1 {
+				break // WM_QUIT, or GetMessage failed; either way, stop pumping
+			}
+			if m.Message == wmHotkey {
+				idx := int(m.WParam) - 1
+				if idx >= 0 && idx < len(parsed) {
+					onFire(parsed[idx].chord)
+				}
+			}
+		}
+
+		for i := range parsed {
+			procUnregisterHotKey.Call(0, uintptr(i+1))
+		}
+	}()
+
+	if err := <-ready; err != nil {
+		return nil, err
+	}
+	tid := <-threadID
+
+	stop = func() {
+		procPostThreadMessageW.Call(uintptr(tid), wmQuitW, 0, 0)
+	}
+	return stop, nil
+}
@@ -3,7 +3,9 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 	"sync"
@@ -399,6 +401,15 @@ func truncateRunes(s string, n int) string {
 }
 
 func main() {
+	tuiFlag := flag.Bool("tui", false, "run the headless terminal UI instead of the GUI")
+	flag.Parse()
+	if shouldRunTUI(*tuiFlag, false) {
+		if err := runTUI(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	myApp := app.New()
 	myApp.Settings().SetTheme(theme.DarkTheme())
 
@@ -444,7 +455,6 @@ func main() {
 	}, nil)
 	layoutSelect.Selected = "Auto (Use System)"
 
-	winOptions := []string{}
 	winMap := map[string]windows.Handle{}
 
 	var laMu sync.RWMutex
@@ -454,52 +464,71 @@ func main() {
 	_ = lastActiveText.Set("Last active: (none)")
 	lastActiveLabel := widget.NewLabelWithData(lastActiveText)
 
-	windowSelect := widget.NewSelect(winOptions, nil)
-	windowSelect.PlaceHolder = "None (use last active)"
+	windowPicker := newFuzzyWindowPicker(nil)
+
+	snippetLib, err := loadSnippetLibrary()
+	if err != nil {
+		log.Println("snippets: failed to load library:", err)
+		snippetLib = &snippetLibrary{}
+	}
+	var stopHotkeys func()
+	reloadHotkeys := func() {
+		if stopHotkeys != nil {
+			stopHotkeys()
+		}
+		stop, err := registerSnippetHotkeys(snippetLib)
+		if err != nil {
+			log.Println("snippets: hotkeys unavailable:", err)
+			stop = func() {}
+		}
+		stopHotkeys = stop
+	}
+	snippetPanel := newSnippetPanel(snippetLib,
+		func() string { return inputEntry.Text },
+		func(text string) { inputEntry.SetText(text) },
+		func(msg string) { status.SetText(msg) },
+		reloadHotkeys,
+	)
+	reloadHotkeys()
+	defer stopHotkeys()
 
 	clearBtn := widget.NewButton("Clear", func() {
-		windowSelect.Selected = ""
-		windowSelect.Refresh()
+		windowPicker.Clear()
 		status.SetText("Selection cleared → using last active window.")
 	})
 
 	refreshWindows := func() {
 		wins := enumWindows()
-		winOptions = []string{}
+		entries := make([]windowPickerEntry, 0, len(wins))
 		winMap = map[string]windows.Handle{}
 		for _, wi := range wins {
-			label := fmt.Sprintf("%s (0x%X)", wi.Title, uintptr(wi.Hwnd))
-			winOptions = append(winOptions, label)
-			winMap[label] = wi.Hwnd
+			key := fmt.Sprintf("0x%X", uintptr(wi.Hwnd))
+			entries = append(entries, windowPickerEntry{
+				Label: fmt.Sprintf("%s (%s)", wi.Title, key),
+				Key:   key,
+			})
+			winMap[key] = wi.Hwnd
 		}
-		windowSelect.Options = winOptions
-		windowSelect.Refresh()
+		windowPicker.SetEntries(entries)
 		status.SetText(fmt.Sprintf("Found %d windows.", len(wins)))
 	}
 
 	refreshBtn := widget.NewButton("Refresh windows", refreshWindows)
 
 	go func() {
-		for {
-			hwnd := getForeground()
-			if hwnd != 0 {
-				title := strings.TrimSpace(getWindowText(hwnd))
-				if title != "" && title != w.Title() {
-					// apply 255-char rune limit
-					t := truncateRunes(title, 255)
-					laMu.Lock()
-					lastActiveHandle = hwnd
-					lastActiveTitle = t
-					laMu.Unlock()
-					_ = lastActiveText.Set("Last active: " + t)
-				}
-			}
-			time.Sleep(300 * time.Millisecond)
+		for update := range LastActiveSubscribe() {
+			var hwnd windows.Handle
+			fmt.Sscanf(update.ID, "0x%X", &hwnd)
+			laMu.Lock()
+			lastActiveHandle = hwnd
+			lastActiveTitle = update.Title
+			laMu.Unlock()
+			_ = lastActiveText.Set("Last active: " + update.Title)
 		}
 	}()
 
 	typeBtn := widget.NewButton("Type", func() {
-		selected := windowSelect.Selected
+		selected := windowPicker.Selected()
 
 		laMu.RLock()
 		curH := lastActiveHandle
@@ -547,8 +576,9 @@ func main() {
 
 	left := container.NewVBox(
 		widget.NewLabelWithStyle("Target Window", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		container.NewHBox(windowSelect, clearBtn),
-		refreshBtn,
+		windowPicker.Search,
+		windowPicker.List,
+		container.NewHBox(refreshBtn, clearBtn),
 		lastActiveLabel,
 	)
 
@@ -568,9 +598,118 @@ func main() {
 		status,
 	)
 
-	content := container.NewBorder(header, nil, nil, nil, body)
+	content := container.NewBorder(header, nil, nil, snippetPanel.Root, body)
 	w.SetContent(content)
 
 	refreshWindows()
 	w.ShowAndRun()
 }
+
+// ListWindows returns the OS-agnostic view of enumWindows' results, for
+// frontends (the tcell TUI) that don't need the raw Handle.
+func ListWindows() ([]WindowInfo, error) {
+	wins := enumWindows()
+	out := make([]WindowInfo, len(wins))
+	for i, wi := range wins {
+		out[i] = WindowInfo{ID: fmt.Sprintf("0x%X", uintptr(wi.Hwnd)), Title: wi.Title}
+	}
+	return out, nil
+}
+
+// TypeText types text into the window identified by target (a ListWindows
+// ID), or the current foreground window if target is "".
+func TypeText(target, text string) error {
+	var hwnd windows.Handle
+	if target == "" {
+		hwnd = getForeground()
+	} else {
+		var v uint64
+		if _, err := fmt.Sscanf(target, "0x%X", &v); err != nil {
+			return fmt.Errorf("invalid window id %q: %w", target, err)
+		}
+		hwnd = windows.Handle(v)
+	}
+	if hwnd == 0 {
+		return fmt.Errorf("no target window")
+	}
+
+	setForegroundWindow(hwnd)
+	time.Sleep(150 * time.Millisecond)
+	return sendText(text, "Auto (Use System)", 7*time.Millisecond)
+}
+
+const vkLeft = 0x25
+
+// moveCaretLeft taps the Left arrow n times against hkl's layout, used to
+// walk the caret back to a snippet's {{cursor}} marker after typing it.
+func moveCaretLeft(n int, hkl windows.Handle) error {
+	sc := mapVirtualKeyEx(vkLeft, hkl)
+	for i := 0; i < n; i++ {
+		if err := tapScan(sc, isExtendedVK(vkLeft)); err != nil {
+			return err
+		}
+		time.Sleep(7 * time.Millisecond)
+	}
+	return nil
+}
+
+// typeSnippet types a fired snippet's already-expanded text, honoring the
+// snippet's LayoutHint, then walks the caret back over any {{cursor}} offset.
+func typeSnippet(s Snippet, text string, caretBack int) error {
+	if getForeground() == 0 {
+		return fmt.Errorf("no foreground window")
+	}
+	layout := s.LayoutHint
+	if layout == "" {
+		layout = "Auto (Use System)"
+	}
+	if err := sendText(text, layout, 7*time.Millisecond); err != nil {
+		return err
+	}
+	if caretBack > 0 {
+		return moveCaretLeft(caretBack, loadHKLByName(layout))
+	}
+	return nil
+}
+
+var (
+	lastActiveMu   sync.Mutex
+	lastActiveSubs []chan WindowInfo
+	lastActiveOnce sync.Once
+)
+
+// LastActiveSubscribe starts (once) a poll loop over the foreground window
+// and returns a channel with an update each time it changes to a new,
+// titled window other than goclip's own. Safe to call more than once.
+func LastActiveSubscribe() <-chan WindowInfo {
+	ch := make(chan WindowInfo, 1)
+	lastActiveMu.Lock()
+	lastActiveSubs = append(lastActiveSubs, ch)
+	lastActiveMu.Unlock()
+
+	lastActiveOnce.Do(func() {
+		go func() {
+			var last windows.Handle
+			for {
+				hwnd := getForeground()
+				if hwnd != 0 && hwnd != last {
+					title := strings.TrimSpace(getWindowText(hwnd))
+					if title != "" && title != "goclip" {
+						last = hwnd
+						update := WindowInfo{ID: fmt.Sprintf("0x%X", uintptr(hwnd)), Title: truncateRunes(title, 255)}
+						lastActiveMu.Lock()
+						for _, sub := range lastActiveSubs {
+							select {
+							case sub <- update:
+							default:
+							}
+						}
+						lastActiveMu.Unlock()
+					}
+				}
+				time.Sleep(300 * time.Millisecond)
+			}
+		}()
+	})
+	return ch
+}
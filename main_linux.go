@@ -4,6 +4,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,12 +14,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/bendahl/uinput"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/bendahl/uinput"
 )
 
 type windowInfo struct {
@@ -79,11 +80,14 @@ func listWindows() ([]windowInfo, error) {
 // typeText sends text to a window (X11) or globally (uinput)
 func typeText(windowID, text string) error {
 	if backend == "x11" {
+		// --clearmodifiers so a hotkey-triggered paste fired while the user
+		// still physically holds the chord's modifiers doesn't type every
+		// character Shift/Ctrl/Alt-combined.
 		if windowID == "" {
-			return exec.Command("xdotool", "type", "--delay", "7", text).Run()
+			return exec.Command("xdotool", "type", "--clearmodifiers", "--delay", "7", text).Run()
 		}
 		exec.Command("xdotool", "windowactivate", "--sync", windowID).Run()
-		return exec.Command("xdotool", "type", "--window", windowID, "--delay", "7", text).Run()
+		return exec.Command("xdotool", "type", "--window", windowID, "--clearmodifiers", "--delay", "7", text).Run()
 	}
 
 	if backend == "uinput" {
@@ -93,7 +97,24 @@ func typeText(windowID, text string) error {
 	return fmt.Errorf("unsupported backend")
 }
 
-// typeWithUinput injects keystrokes via /dev/uinput
+var (
+	xkbOnce sync.Once
+	xkbT    *xkbTranslator
+	xkbErr  error
+)
+
+// getXKBTranslator lazily builds the process-wide XKB translator for the
+// active layout. It is built once per process and never rebuilt, so a layout
+// change after the first call is not picked up.
+func getXKBTranslator() (*xkbTranslator, error) {
+	xkbOnce.Do(func() {
+		xkbT, xkbErr = newXKBTranslator()
+	})
+	return xkbT, xkbErr
+}
+
+// typeWithUinput injects keystrokes via /dev/uinput, translating each rune
+// through the active XKB layout instead of just unshifted ASCII.
 func typeWithUinput(text string) error {
 	keyboard, err := uinput.CreateKeyboard("/dev/uinput", []byte("goclip-virtual-keyboard"))
 	if err != nil {
@@ -101,113 +122,97 @@ func typeWithUinput(text string) error {
 	}
 	defer keyboard.Close()
 
+	xkb, err := getXKBTranslator()
+	if err != nil {
+		return fmt.Errorf("xkb: %w", err)
+	}
+
 	for _, r := range text {
-		key, shift := runeToKey(r)
-		if key == 0 {
+		if r == '\n' {
+			if err := keyboard.KeyPress(uinput.KeyEnter); err != nil {
+				return err
+			}
+			time.Sleep(7 * time.Millisecond)
 			continue
 		}
-		if shift {
-			_ = keyboard.KeyDown(uinput.KeyLeftshift)
-		}
-		if err := keyboard.KeyPress(key); err != nil {
+		if err := typeRuneWithUinput(keyboard, xkb, r); err != nil {
 			return err
 		}
-		if shift {
-			_ = keyboard.KeyUp(uinput.KeyLeftshift)
-		}
 		time.Sleep(7 * time.Millisecond)
 	}
 	return nil
 }
 
-// runeToKey maps runes to uinput key codes (basic ASCII)
-func runeToKey(r rune) (int, bool) {
-	switch r {
-	case 'a', 'A':
-		return uinput.KeyA, r == 'A'
-	case 'b', 'B':
-		return uinput.KeyB, r == 'B'
-	case 'c', 'C':
-		return uinput.KeyC, r == 'C'
-	case 'd', 'D':
-		return uinput.KeyD, r == 'D'
-	case 'e', 'E':
-		return uinput.KeyE, r == 'E'
-	case 'f', 'F':
-		return uinput.KeyF, r == 'F'
-	case 'g', 'G':
-		return uinput.KeyG, r == 'G'
-	case 'h', 'H':
-		return uinput.KeyH, r == 'H'
-	case 'i', 'I':
-		return uinput.KeyI, r == 'I'
-	case 'j', 'J':
-		return uinput.KeyJ, r == 'J'
-	case 'k', 'K':
-		return uinput.KeyK, r == 'K'
-	case 'l', 'L':
-		return uinput.KeyL, r == 'L'
-	case 'm', 'M':
-		return uinput.KeyM, r == 'M'
-	case 'n', 'N':
-		return uinput.KeyN, r == 'N'
-	case 'o', 'O':
-		return uinput.KeyO, r == 'O'
-	case 'p', 'P':
-		return uinput.KeyP, r == 'P'
-	case 'q', 'Q':
-		return uinput.KeyQ, r == 'Q'
-	case 'r', 'R':
-		return uinput.KeyR, r == 'R'
-	case 's', 'S':
-		return uinput.KeyS, r == 'S'
-	case 't', 'T':
-		return uinput.KeyT, r == 'T'
-	case 'u', 'U':
-		return uinput.KeyU, r == 'U'
-	case 'v', 'V':
-		return uinput.KeyV, r == 'V'
-	case 'w', 'W':
-		return uinput.KeyW, r == 'W'
-	case 'x', 'X':
-		return uinput.KeyX, r == 'X'
-	case 'y', 'Y':
-		return uinput.KeyY, r == 'Y'
-	case 'z', 'Z':
-		return uinput.KeyZ, r == 'Z'
-	case ' ':
-		return uinput.KeySpace, false
-	case '\n':
-		return uinput.KeyEnter, false
-	case '0':
-		return uinput.Key0, false
-	case '1':
-		return uinput.Key1, false
-	case '2':
-		return uinput.Key2, false
-	case '3':
-		return uinput.Key3, false
-	case '4':
-		return uinput.Key4, false
-	case '5':
-		return uinput.Key5, false
-	case '6':
-		return uinput.Key6, false
-	case '7':
-		return uinput.Key7, false
-	case '8':
-		return uinput.Key8, false
-	case '9':
-		return uinput.Key9, false
-	default:
-		return 0, false
+// typeRuneWithUinput types a single rune, preferring the direct XKB lookup,
+// then the dead-key compose fallback, then xdotool's Unicode key path.
+func typeRuneWithUinput(keyboard uinput.Keyboard, xkb *xkbTranslator, r rune) error {
+	if spot, ok := xkb.lookup(r); ok {
+		return pressSpot(keyboard, xkb, spot)
+	}
+
+	if spots, ok := xkb.typeRuneViaCompose(r); ok {
+		for _, spot := range spots {
+			if err := pressSpot(keyboard, xkb, spot); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return sendRuneViaXdotoolUnicode(r)
+}
+
+// pressSpot holds the modifiers a keySpot calls for, taps its keycode, then
+// releases them. XKB keycodes are evdev keycodes offset by 8, hence the -8.
+func pressSpot(keyboard uinput.Keyboard, xkb *xkbTranslator, spot keySpot) error {
+	code := int(spot.keycode) - 8
+	if spot.mods&modShift != 0 {
+		if err := keyboard.KeyDown(xkb.shiftUinputCode()); err != nil {
+			return err
+		}
+	}
+	if spot.mods&modAltGr != 0 {
+		if err := keyboard.KeyDown(xkb.altGrUinputCode()); err != nil {
+			return err
+		}
+	}
+
+	err := keyboard.KeyPress(code)
+
+	if spot.mods&modAltGr != 0 {
+		_ = keyboard.KeyUp(xkb.altGrUinputCode())
+	}
+	if spot.mods&modShift != 0 {
+		_ = keyboard.KeyUp(xkb.shiftUinputCode())
 	}
+	return err
+}
+
+// sendRuneViaXdotoolUnicode emits a single rune through xdotool's Unicode
+// keysym syntax, the last resort for codepoints XKB/uinput can't produce
+// directly. xdotool needs an X11 display, so on the uinput backend
+// (Wayland, or no display) there's nothing to shell out to; report that
+// instead of running a command that can only fail to connect.
+func sendRuneViaXdotoolUnicode(r rune) error {
+	if backend != "x11" {
+		return fmt.Errorf("cannot type %q: no Unicode input path on the uinput/Wayland backend", r)
+	}
+	return exec.Command("xdotool", "key", "--clearmodifiers", fmt.Sprintf("U%04X", r)).Run()
 }
 
 func main() {
 	backend = detectBackend()
 	log.Println("Detected backend:", backend)
 
+	tuiFlag := flag.Bool("tui", false, "run the headless terminal UI instead of the GUI")
+	flag.Parse()
+	if shouldRunTUI(*tuiFlag, true) {
+		if err := runTUI(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	myApp := app.New()
 	myApp.Settings().SetTheme(theme.DarkTheme())
 
@@ -220,10 +225,34 @@ func main() {
 
 	status := widget.NewLabel("Ready.")
 
-	windowOptions := []string{}
 	windowMap := map[string]string{}
-	windowSelect := widget.NewSelect(windowOptions, nil)
-	windowSelect.PlaceHolder = "None (use last active)"
+	windowPicker := newFuzzyWindowPicker(nil)
+
+	snippetLib, err := loadSnippetLibrary()
+	if err != nil {
+		log.Println("snippets: failed to load library:", err)
+		snippetLib = &snippetLibrary{}
+	}
+	var stopHotkeys func()
+	reloadHotkeys := func() {
+		if stopHotkeys != nil {
+			stopHotkeys()
+		}
+		stop, err := registerSnippetHotkeys(snippetLib)
+		if err != nil {
+			log.Println("snippets: hotkeys unavailable:", err)
+			stop = func() {}
+		}
+		stopHotkeys = stop
+	}
+	snippetPanel := newSnippetPanel(snippetLib,
+		func() string { return inputEntry.Text },
+		func(text string) { inputEntry.SetText(text) },
+		func(msg string) { status.SetText(msg) },
+		reloadHotkeys,
+	)
+	reloadHotkeys()
+	defer stopHotkeys()
 
 	var laMu sync.RWMutex
 	lastActiveTitle := "(none)"
@@ -240,43 +269,28 @@ func main() {
 			status.SetText("Error listing windows: " + err.Error())
 			return
 		}
-		windowOptions = []string{}
+		entries := make([]windowPickerEntry, 0, len(wins))
 		windowMap = map[string]string{}
 		for _, wi := range wins {
-			label := fmt.Sprintf("%s (%s)", truncateRunes(wi.Title, 30), wi.ID)
-			windowOptions = append(windowOptions, label)
-			windowMap[label] = wi.ID
+			entries = append(entries, windowPickerEntry{
+				Label: fmt.Sprintf("%s (%s)", truncateRunes(wi.Title, 30), wi.ID),
+				Key:   wi.ID,
+			})
+			windowMap[wi.ID] = wi.ID
 		}
-		windowSelect.Options = windowOptions
-		windowSelect.Refresh()
+		windowPicker.SetEntries(entries)
 		status.SetText(fmt.Sprintf("Found %d windows.", len(wins)))
 	})
 
 	// Track last active window (X11 only)
 	if backend == "x11" {
 		go func() {
-			for {
-				cmd := exec.Command("xdotool", "getactivewindow")
-				out, err := cmd.Output()
-				if err == nil {
-					id := strings.TrimSpace(string(out))
-					if id != "" {
-						nameCmd := exec.Command("xdotool", "getwindowname", id)
-						var buf bytes.Buffer
-						nameCmd.Stdout = &buf
-						if err := nameCmd.Run(); err == nil {
-							title := strings.TrimSpace(buf.String())
-							if title != "" {
-								laMu.Lock()
-								lastActiveID = id
-								lastActiveTitle = truncateRunes(title, 30)
-								laMu.Unlock()
-								lastActiveLabel.SetText("Last active: " + lastActiveTitle)
-							}
-						}
-					}
-				}
-				time.Sleep(500 * time.Millisecond)
+			for update := range LastActiveSubscribe() {
+				laMu.Lock()
+				lastActiveID = update.ID
+				lastActiveTitle = update.Title
+				laMu.Unlock()
+				lastActiveLabel.SetText("Last active: " + lastActiveTitle)
 			}
 		}()
 	}
@@ -294,11 +308,11 @@ func main() {
 		laMu.RUnlock()
 
 		var targetID string
-		if windowSelect.Selected == "" {
+		if windowPicker.Selected() == "" {
 			targetID = curID
 		} else {
 			var ok bool
-			targetID, ok = windowMap[windowSelect.Selected]
+			targetID, ok = windowMap[windowPicker.Selected()]
 			if !ok {
 				status.SetText("Selected window no longer available.")
 				return
@@ -321,7 +335,9 @@ func main() {
 
 	left := container.NewVBox(
 		widget.NewLabelWithStyle("Target Window", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		container.NewHBox(windowSelect, refreshBtn),
+		windowPicker.Search,
+		windowPicker.List,
+		refreshBtn,
 		lastActiveLabel,
 	)
 
@@ -332,7 +348,7 @@ func main() {
 		status,
 	)
 
-	content := container.NewBorder(left, nil, nil, nil, body)
+	content := container.NewBorder(left, nil, nil, snippetPanel.Root, body)
 	w.SetContent(content)
 
 	if backend == "x11" {
@@ -350,4 +366,119 @@ func truncateRunes(s string, n int) string {
 		return string(r[:n])
 	}
 	return string(r[:n]) + "..."
-}
\ No newline at end of file
+}
+
+// ListWindows returns the OS-agnostic view of listWindows' results; an
+// empty list on Wayland/uinput, where window enumeration isn't supported.
+func ListWindows() ([]WindowInfo, error) {
+	if backend != "x11" {
+		return nil, nil
+	}
+	wins, err := listWindows()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]WindowInfo, len(wins))
+	for i, wi := range wins {
+		out[i] = WindowInfo{ID: wi.ID, Title: wi.Title}
+	}
+	return out, nil
+}
+
+// TypeText types text into the window identified by target (a ListWindows
+// ID), or the currently focused window if target is "".
+func TypeText(target, text string) error {
+	return typeText(target, text)
+}
+
+// moveCaretLeft taps the Left arrow n times, used to walk the caret back to
+// a snippet's {{cursor}} marker after typing it.
+func moveCaretLeft(n int) error {
+	if backend == "x11" {
+		for i := 0; i < n; i++ {
+			if err := exec.Command("xdotool", "key", "Left").Run(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	keyboard, err := uinput.CreateKeyboard("/dev/uinput", []byte("goclip-virtual-keyboard"))
+	if err != nil {
+		return fmt.Errorf("failed to create uinput keyboard: %w", err)
+	}
+	defer keyboard.Close()
+	for i := 0; i < n; i++ {
+		if err := keyboard.KeyPress(uinput.KeyLeft); err != nil {
+			return err
+		}
+		time.Sleep(7 * time.Millisecond)
+	}
+	return nil
+}
+
+// typeSnippet types a fired snippet's already-expanded text, then walks the
+// caret back over any {{cursor}} offset. LayoutHint is ignored on Linux.
+func typeSnippet(s Snippet, text string, caretBack int) error {
+	if err := typeText("", text); err != nil {
+		return err
+	}
+	if caretBack > 0 {
+		return moveCaretLeft(caretBack)
+	}
+	return nil
+}
+
+var (
+	lastActiveMu   sync.Mutex
+	lastActiveSubs []chan WindowInfo
+	lastActiveOnce sync.Once
+)
+
+// LastActiveSubscribe starts (once) a poll loop over xdotool's active
+// window (X11 only) and returns a channel with an update each time it
+// changes; never fires on Wayland/uinput. Safe to call more than once.
+func LastActiveSubscribe() <-chan WindowInfo {
+	ch := make(chan WindowInfo, 1)
+	if backend != "x11" {
+		return ch
+	}
+
+	lastActiveMu.Lock()
+	lastActiveSubs = append(lastActiveSubs, ch)
+	lastActiveMu.Unlock()
+
+	lastActiveOnce.Do(func() {
+		go func() {
+			var lastID string
+			for {
+				out, err := exec.Command("xdotool", "getactivewindow").Output()
+				if err == nil {
+					id := strings.TrimSpace(string(out))
+					if id != "" && id != lastID {
+						var buf bytes.Buffer
+						nameCmd := exec.Command("xdotool", "getwindowname", id)
+						nameCmd.Stdout = &buf
+						if err := nameCmd.Run(); err == nil {
+							title := strings.TrimSpace(buf.String())
+							if title != "" {
+								lastID = id
+								update := WindowInfo{ID: id, Title: truncateRunes(title, 30)}
+								lastActiveMu.Lock()
+								for _, sub := range lastActiveSubs {
+									select {
+									case sub <- update:
+									default:
+									}
+								}
+								lastActiveMu.Unlock()
+							}
+						}
+					}
+				}
+				time.Sleep(500 * time.Millisecond)
+			}
+		}()
+	})
+	return ch
+}
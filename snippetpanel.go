@@ -0,0 +1,195 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// doubleTapWindow is how close together two taps on the same snippet row
+// have to land to count as a double-click-to-load, mirroring typical OS
+// double-click timings.
+const doubleTapWindow = 400 * time.Millisecond
+
+// snippetPanel is the snippet library UI embedded in both Fyne frontends: a
+// fuzzy-filtered list of saved snippets with Save/Delete buttons and
+// double-click-to-load into the caller's text entry.
+type snippetPanel struct {
+	Root fyne.CanvasObject
+
+	Search          *widget.Entry
+	NameEntry       *widget.Entry
+	HotkeyEntry     *widget.Entry
+	TagsEntry       *widget.Entry
+	LayoutHintEntry *widget.Entry
+	List            *widget.List
+
+	lib      *snippetLibrary
+	names    []string
+	filtered []fuzzyResult
+
+	lastTapID   widget.ListItemID
+	lastTapTime time.Time
+
+	getText   func() string
+	setText   func(string)
+	status    func(string)
+	onChanged func()
+}
+
+// newSnippetPanel builds a snippetPanel backed by lib. getText/setText wire
+// the panel to the caller's main text entry; onChanged fires after any save
+// or delete so the caller can re-register hotkeys.
+func newSnippetPanel(lib *snippetLibrary, getText func() string, setText func(string), status func(string), onChanged func()) *snippetPanel {
+	p := &snippetPanel{lib: lib, getText: getText, setText: setText, status: status, onChanged: onChanged, lastTapID: -1}
+
+	p.Search = widget.NewEntry()
+	p.Search.SetPlaceHolder("Filter snippets…")
+	p.NameEntry = widget.NewEntry()
+	p.NameEntry.SetPlaceHolder("Snippet name…")
+	p.HotkeyEntry = widget.NewEntry()
+	p.HotkeyEntry.SetPlaceHolder("Hotkey, e.g. Ctrl+Alt+1…")
+	p.TagsEntry = widget.NewEntry()
+	p.TagsEntry.SetPlaceHolder("Tags, comma-separated…")
+	p.LayoutHintEntry = widget.NewEntry()
+	p.LayoutHintEntry.SetPlaceHolder("Layout hint (Windows only)…")
+
+	p.List = widget.NewList(
+		func() int { return len(p.filtered) },
+		func() fyne.CanvasObject { return widget.NewRichText() },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			rt := obj.(*widget.RichText)
+			res := p.filtered[id]
+			rt.Segments = richTextSegments(p.names[res.Index], res.Positions)
+			rt.Refresh()
+		},
+	)
+
+	p.Search.OnChanged = func(q string) { p.refresh(q) }
+	p.List.OnSelected = p.handleTap
+
+	saveBtn := widget.NewButton("Save", p.save)
+	deleteBtn := widget.NewButton("Delete", p.delete)
+
+	p.Root = container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("Snippets", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			p.Search,
+		),
+		container.NewVBox(
+			p.NameEntry, p.HotkeyEntry, p.TagsEntry, p.LayoutHintEntry,
+			container.NewHBox(saveBtn, deleteBtn),
+		),
+		nil, nil,
+		p.List,
+	)
+
+	p.refresh("")
+	return p
+}
+
+// handleTap loads the tapped row's name into NameEntry, and on a second tap
+// within doubleTapWindow also loads its text into the main entry.
+func (p *snippetPanel) handleTap(id widget.ListItemID) {
+	defer p.List.Unselect(id)
+
+	name := p.names[p.filtered[id].Index]
+	p.NameEntry.SetText(name)
+
+	now := time.Now()
+	doubleTap := id == p.lastTapID && now.Sub(p.lastTapTime) < doubleTapWindow
+	p.lastTapID, p.lastTapTime = id, now
+
+	if !doubleTap {
+		return
+	}
+	p.lastTapID = -1
+
+	s, ok := p.lib.Find(name)
+	if !ok {
+		return
+	}
+	p.setText(s.Text)
+	p.HotkeyEntry.SetText(s.Hotkey)
+	p.TagsEntry.SetText(strings.Join(s.Tags, ", "))
+	p.LayoutHintEntry.SetText(s.LayoutHint)
+	p.status("Loaded snippet: " + s.Name)
+}
+
+func (p *snippetPanel) save() {
+	name := p.NameEntry.Text
+	if name == "" {
+		p.status("Snippet needs a name.")
+		return
+	}
+	hotkey := strings.TrimSpace(p.HotkeyEntry.Text)
+	if hotkey != "" {
+		if _, err := parseHotkeyChord(hotkey); err != nil {
+			p.status("Invalid hotkey: " + err.Error())
+			return
+		}
+	}
+
+	existing, _ := p.lib.Find(name)
+	existing.Name = name
+	existing.Text = p.getText()
+	existing.Hotkey = hotkey
+	existing.LayoutHint = strings.TrimSpace(p.LayoutHintEntry.Text)
+	existing.Tags = splitTags(p.TagsEntry.Text)
+	if err := p.lib.Upsert(existing); err != nil {
+		p.status("Error saving snippet: " + err.Error())
+		return
+	}
+	p.status("Saved snippet: " + name)
+	p.refresh(p.Search.Text)
+	if p.onChanged != nil {
+		p.onChanged()
+	}
+}
+
+func (p *snippetPanel) delete() {
+	name := p.NameEntry.Text
+	if name == "" {
+		p.status("No snippet selected.")
+		return
+	}
+	if err := p.lib.Delete(name); err != nil {
+		p.status("Error deleting snippet: " + err.Error())
+		return
+	}
+	p.NameEntry.SetText("")
+	p.HotkeyEntry.SetText("")
+	p.TagsEntry.SetText("")
+	p.LayoutHintEntry.SetText("")
+	p.status("Deleted snippet: " + name)
+	p.refresh(p.Search.Text)
+	if p.onChanged != nil {
+		p.onChanged()
+	}
+}
+
+func (p *snippetPanel) refresh(query string) {
+	snippets := p.lib.All()
+	p.names = make([]string, len(snippets))
+	for i, s := range snippets {
+		p.names[i] = s.Name
+	}
+	p.filtered = fuzzyFilter(query, p.names)
+	p.List.Refresh()
+}
+
+// splitTags parses the comma-separated Tags field into a slice, dropping
+// empty entries.
+func splitTags(raw string) []string {
+	var tags []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Snippet is one saved block of text the user can paste, either from the UI
+// list or via its assigned global hotkey.
+type Snippet struct {
+	Name       string   `json:"name"`
+	Text       string   `json:"text"`
+	Tags       []string `json:"tags,omitempty"`
+	LayoutHint string   `json:"layoutHint,omitempty"`
+	Hotkey     string   `json:"hotkey,omitempty"` // e.g. "Ctrl+Alt+1"
+}
+
+// snippetLibrary is goclip's on-disk snippet store, keyed by Name.
+type snippetLibrary struct {
+	mu       sync.Mutex
+	path     string
+	Snippets []Snippet `json:"snippets"`
+}
+
+// snippetLibraryPath returns the path to goclip's snippets.json, creating
+// its containing directory if needed.
+func snippetLibraryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("snippets: %w", err)
+	}
+	dir = filepath.Join(dir, "goclip")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("snippets: %w", err)
+	}
+	return filepath.Join(dir, "snippets.json"), nil
+}
+
+// loadSnippetLibrary reads snippets.json, treating a missing file as an
+// empty library rather than an error (first run).
+func loadSnippetLibrary() (*snippetLibrary, error) {
+	path, err := snippetLibraryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	lib := &snippetLibrary{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lib, nil
+		}
+		return nil, fmt.Errorf("snippets: %w", err)
+	}
+	if err := json.Unmarshal(data, lib); err != nil {
+		return nil, fmt.Errorf("snippets: %w", err)
+	}
+	return lib, nil
+}
+
+func (l *snippetLibrary) save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("snippets: %w", err)
+	}
+	return os.WriteFile(l.path, data, 0o644)
+}
+
+// All returns a copy of the library's snippets, sorted by name, safe for a
+// caller to range over without holding the library lock.
+func (l *snippetLibrary) All() []Snippet {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]Snippet, len(l.Snippets))
+	copy(out, l.Snippets)
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out
+}
+
+// Find looks up a snippet by name.
+func (l *snippetLibrary) Find(name string) (Snippet, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, s := range l.Snippets {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Snippet{}, false
+}
+
+// Upsert saves s, replacing any existing snippet with the same name.
+func (l *snippetLibrary) Upsert(s Snippet) error {
+	l.mu.Lock()
+	replaced := false
+	for i, existing := range l.Snippets {
+		if existing.Name == s.Name {
+			l.Snippets[i] = s
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		l.Snippets = append(l.Snippets, s)
+	}
+	l.mu.Unlock()
+
+	return l.save()
+}
+
+// Delete removes the snippet with the given name, if any.
+func (l *snippetLibrary) Delete(name string) error {
+	l.mu.Lock()
+	for i, existing := range l.Snippets {
+		if existing.Name == name {
+			l.Snippets = append(l.Snippets[:i], l.Snippets[i+1:]...)
+			break
+		}
+	}
+	l.mu.Unlock()
+
+	return l.save()
+}
+
+// findByHotkey looks up the snippet bound to chord, if any.
+func (l *snippetLibrary) findByHotkey(chord string) (Snippet, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, s := range l.Snippets {
+		if s.Hotkey == chord {
+			return s, true
+		}
+	}
+	return Snippet{}, false
+}
+
+// registerSnippetHotkeys starts one watchHotkeys watcher covering every
+// snippet with a valid Hotkey, typing the fired snippet into the foreground
+// window. Snippets with an unparseable Hotkey are skipped rather than
+// passed to watchHotkeys, so one bad chord can't disable the rest.
+func registerSnippetHotkeys(lib *snippetLibrary) (stop func(), err error) {
+	var chords []string
+	for _, s := range lib.All() {
+		if s.Hotkey == "" {
+			continue
+		}
+		if _, err := parseHotkeyChord(s.Hotkey); err != nil {
+			continue
+		}
+		chords = append(chords, s.Hotkey)
+	}
+	if len(chords) == 0 {
+		return func() {}, nil
+	}
+
+	return watchHotkeys(chords, func(chord string) {
+		s, ok := lib.findByHotkey(chord)
+		if !ok {
+			return
+		}
+		text, caretBack := expandSnippetTemplate(s.Text)
+		_ = typeSnippet(s, text, caretBack)
+	})
+}
+
+const cursorMarker = "{{cursor}}"
+
+// expandSnippetTemplate evaluates {{date}}/{{clipboard}} and strips
+// {{cursor}}, returning the text to type and how many runes back from the
+// end the caret should land (0 if there was no {{cursor}} marker).
+func expandSnippetTemplate(text string) (expanded string, caretBack int) {
+	text = strings.ReplaceAll(text, "{{date}}", time.Now().Format("2006-01-02"))
+	if strings.Contains(text, "{{clipboard}}") {
+		clip, err := readClipboard()
+		if err != nil {
+			clip = ""
+		}
+		text = strings.ReplaceAll(text, "{{clipboard}}", clip)
+	}
+
+	idx := strings.Index(text, cursorMarker)
+	if idx < 0 {
+		return text, 0
+	}
+	text = text[:idx] + text[idx+len(cursorMarker):]
+	return text, len([]rune(text[idx:]))
+}
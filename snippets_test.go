@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandSnippetTemplateDate(t *testing.T) {
+	expanded, caretBack := expandSnippetTemplate("Today is {{date}}.")
+	want := "Today is " + time.Now().Format("2006-01-02") + "."
+	if expanded != want {
+		t.Fatalf("expanded = %q, want %q", expanded, want)
+	}
+	if caretBack != 0 {
+		t.Fatalf("caretBack = %d, want 0 (no {{cursor}} marker)", caretBack)
+	}
+}
+
+func TestExpandSnippetTemplateCursorAtEnd(t *testing.T) {
+	expanded, caretBack := expandSnippetTemplate("Hello{{cursor}}")
+	if expanded != "Hello" {
+		t.Fatalf("expanded = %q, want %q", expanded, "Hello")
+	}
+	if caretBack != 0 {
+		t.Fatalf("caretBack = %d, want 0 (marker was at the end)", caretBack)
+	}
+}
+
+func TestExpandSnippetTemplateCursorMidText(t *testing.T) {
+	expanded, caretBack := expandSnippetTemplate("Dear ,{{cursor}}\nBest")
+	wantExpanded := "Dear ,\nBest"
+	if expanded != wantExpanded {
+		t.Fatalf("expanded = %q, want %q", expanded, wantExpanded)
+	}
+	wantBack := len([]rune("\nBest"))
+	if caretBack != wantBack {
+		t.Fatalf("caretBack = %d, want %d", caretBack, wantBack)
+	}
+}
+
+func TestExpandSnippetTemplateCursorWithMultibyteTail(t *testing.T) {
+	expanded, caretBack := expandSnippetTemplate("Grüße{{cursor}}!! café")
+	wantExpanded := "Grüße!! café"
+	if expanded != wantExpanded {
+		t.Fatalf("expanded = %q, want %q", expanded, wantExpanded)
+	}
+	wantBack := len([]rune("!! café"))
+	if caretBack != wantBack {
+		t.Fatalf("caretBack = %d, want %d (rune count, not byte count)", caretBack, wantBack)
+	}
+}
+
+func TestExpandSnippetTemplateClipboardPlaceholderConsumed(t *testing.T) {
+	expanded, _ := expandSnippetTemplate("before {{clipboard}} after")
+	if strings.Contains(expanded, "{{clipboard}}") {
+		t.Fatalf("expanded = %q, {{clipboard}} marker should have been replaced", expanded)
+	}
+	if !strings.HasPrefix(expanded, "before ") || !strings.HasSuffix(expanded, " after") {
+		t.Fatalf("expanded = %q, surrounding text should be preserved", expanded)
+	}
+}
+
+func TestExpandSnippetTemplateNoPlaceholders(t *testing.T) {
+	expanded, caretBack := expandSnippetTemplate("plain text")
+	if expanded != "plain text" || caretBack != 0 {
+		t.Fatalf("expanded = %q, caretBack = %d, want unchanged text and 0", expanded, caretBack)
+	}
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// runTUI drives the same window-picker + text-entry + Type flow as the Fyne
+// UI, but as a full-screen tcell renderer, for use over SSH/tmux.
+func runTUI() error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return fmt.Errorf("tui: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return fmt.Errorf("tui: %w", err)
+	}
+	defer screen.Fini()
+
+	ui := newTUI(screen)
+	ui.refreshWindows()
+	go ui.watchLastActive()
+
+	return ui.run()
+}
+
+type tuiFocus int
+
+const (
+	focusList tuiFocus = iota
+	focusEditor
+)
+
+// tui holds all the state for the tcell frontend: the window list and its
+// fuzzy filter, the editor buffer, and which pane has keyboard focus.
+type tui struct {
+	screen tcell.Screen
+
+	windows    []WindowInfo
+	query      string
+	filtered   []fuzzyResult
+	cursor     int // index into filtered
+	selected   int // index into filtered of the confirmed target, -1 = last active
+	lastActive WindowInfo
+
+	lines  []string
+	ex, ey int // editor cursor: column, row
+
+	status     string
+	focus      tuiFocus
+	filterMode bool
+}
+
+func newTUI(screen tcell.Screen) *tui {
+	return &tui{
+		screen:   screen,
+		selected: -1,
+		lines:    []string{""},
+		status:   "Tab: switch pane  /: filter  Enter: select/newline  Ctrl-T: type  Esc: quit",
+	}
+}
+
+func (u *tui) refreshWindows() {
+	wins, err := ListWindows()
+	if err != nil {
+		u.status = "Error listing windows: " + err.Error()
+	}
+	u.windows = wins
+	u.applyFilter()
+}
+
+func (u *tui) applyFilter() {
+	labels := make([]string, len(u.windows))
+	for i, w := range u.windows {
+		labels[i] = w.Title
+	}
+	u.filtered = fuzzyFilter(u.query, labels)
+	if u.cursor >= len(u.filtered) {
+		u.cursor = len(u.filtered) - 1
+	}
+	if u.cursor < 0 {
+		u.cursor = 0
+	}
+}
+
+// lastActiveEvent carries a LastActiveSubscribe update into run's event loop
+// via tcell's PostEvent, so it's applied on the same goroutine that owns
+// all other tui state instead of racing it.
+type lastActiveEvent struct {
+	t    time.Time
+	info WindowInfo
+}
+
+func (e *lastActiveEvent) When() time.Time { return e.t }
+
+// watchLastActive mirrors LastActiveSubscribe into the event loop as
+// lastActiveEvents.
+func (u *tui) watchLastActive() {
+	for update := range LastActiveSubscribe() {
+		u.screen.PostEvent(&lastActiveEvent{t: time.Now(), info: update})
+	}
+}
+
+func (u *tui) run() error {
+	u.draw()
+	u.screen.Show()
+
+	for {
+		ev := u.screen.PollEvent()
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			u.screen.Sync()
+		case *tcell.EventKey:
+			if quit := u.handleKey(ev); quit {
+				return nil
+			}
+		case *lastActiveEvent:
+			u.lastActive = ev.info
+		}
+		u.draw()
+		u.screen.Show()
+	}
+}
+
+func (u *tui) handleKey(ev *tcell.EventKey) (quit bool) {
+	if u.filterMode {
+		switch ev.Key() {
+		case tcell.KeyEscape:
+			u.filterMode = false
+		case tcell.KeyEnter:
+			u.filterMode = false
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if len(u.query) > 0 {
+				r := []rune(u.query)
+				u.query = string(r[:len(r)-1])
+				u.applyFilter()
+			}
+		case tcell.KeyRune:
+			u.query += string(ev.Rune())
+			u.applyFilter()
+		}
+		return false
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return true
+	case tcell.KeyTab:
+		if u.focus == focusList {
+			u.focus = focusEditor
+		} else {
+			u.focus = focusList
+		}
+		return false
+	case tcell.KeyCtrlT:
+		u.typeBuffer()
+		return false
+	}
+
+	if u.focus == focusList {
+		switch ev.Key() {
+		case tcell.KeyUp:
+			if u.cursor > 0 {
+				u.cursor--
+			}
+		case tcell.KeyDown:
+			if u.cursor < len(u.filtered)-1 {
+				u.cursor++
+			}
+		case tcell.KeyEnter:
+			if len(u.filtered) > 0 {
+				u.selected = u.cursor
+			} else {
+				u.selected = -1
+			}
+			u.focus = focusEditor
+		case tcell.KeyRune:
+			if ev.Rune() == '/' {
+				u.filterMode = true
+			}
+		}
+		return false
+	}
+
+	u.handleEditorKey(ev)
+	return false
+}
+
+func (u *tui) handleEditorKey(ev *tcell.EventKey) {
+	line := u.lines[u.ey]
+	runes := []rune(line)
+
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		rest := string(runes[u.ex:])
+		u.lines[u.ey] = string(runes[:u.ex])
+		tail := append([]string{rest}, u.lines[u.ey+1:]...)
+		u.lines = append(u.lines[:u.ey+1], tail...)
+		u.ey++
+		u.ex = 0
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if u.ex > 0 {
+			u.lines[u.ey] = string(runes[:u.ex-1]) + string(runes[u.ex:])
+			u.ex--
+		} else if u.ey > 0 {
+			prevLen := len([]rune(u.lines[u.ey-1]))
+			u.lines[u.ey-1] += line
+			u.lines = append(u.lines[:u.ey], u.lines[u.ey+1:]...)
+			u.ey--
+			u.ex = prevLen
+		}
+	case tcell.KeyLeft:
+		if u.ex > 0 {
+			u.ex--
+		} else if u.ey > 0 {
+			u.ey--
+			u.ex = len([]rune(u.lines[u.ey]))
+		}
+	case tcell.KeyRight:
+		if u.ex < len(runes) {
+			u.ex++
+		} else if u.ey < len(u.lines)-1 {
+			u.ey++
+			u.ex = 0
+		}
+	case tcell.KeyUp:
+		if u.ey > 0 {
+			u.ey--
+			u.ex = minInt(u.ex, len([]rune(u.lines[u.ey])))
+		}
+	case tcell.KeyDown:
+		if u.ey < len(u.lines)-1 {
+			u.ey++
+			u.ex = minInt(u.ex, len([]rune(u.lines[u.ey])))
+		}
+	case tcell.KeyRune:
+		u.lines[u.ey] = string(runes[:u.ex]) + string(ev.Rune()) + string(runes[u.ex:])
+		u.ex++
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// typeBuffer sends the editor's contents to the selected window (or the
+// last active one, if none is selected) via TypeText.
+func (u *tui) typeBuffer() {
+	text := strings.Join(u.lines, "\n")
+	if text == "" {
+		u.status = "Nothing to type."
+		return
+	}
+
+	target := u.lastActive.ID
+	label := u.lastActive.Title
+	if label == "" {
+		label = "(none)"
+	}
+	if u.selected >= 0 && u.selected < len(u.filtered) {
+		wi := u.windows[u.filtered[u.selected].Index]
+		target = wi.ID
+		label = wi.Title
+	}
+
+	if err := TypeText(target, text); err != nil {
+		u.status = "Error typing: " + err.Error()
+		return
+	}
+	u.status = "Typed to: " + label
+}
+
+func (u *tui) draw() {
+	u.screen.Clear()
+	w, h := u.screen.Size()
+
+	listHeight := h / 3
+	if listHeight < 3 {
+		listHeight = 3
+	}
+
+	u.drawText(0, 0, w, tcell.StyleDefault.Bold(true), "goclip — terminal mode")
+
+	filterLine := "Windows (Tab to switch pane, / to filter):"
+	if u.filterMode {
+		filterLine = "Filter: " + u.query + "_"
+	} else if u.query != "" {
+		filterLine = fmt.Sprintf("Windows (filter: %q):", u.query)
+	}
+	u.drawText(0, 1, w, tcell.StyleDefault, filterLine)
+
+	row := 2
+	for i, res := range u.filtered {
+		if row >= listHeight+2 {
+			break
+		}
+		style := tcell.StyleDefault
+		if u.focus == focusList && i == u.cursor {
+			style = style.Reverse(true)
+		}
+		entry := u.windows[res.Index]
+		u.drawHighlighted(0, row, w, style, entry.Title, res.Positions)
+		row++
+	}
+
+	sep := row
+	if sep < listHeight+2 {
+		sep = listHeight + 2
+	}
+	u.drawText(0, sep, w, tcell.StyleDefault, strings.Repeat("─", w))
+
+	editorTop := sep + 1
+	editorStyle := tcell.StyleDefault
+	for i, line := range u.lines {
+		y := editorTop + i
+		if y >= h-2 {
+			break
+		}
+		u.drawText(0, y, w, editorStyle, line)
+	}
+	if u.focus == focusEditor {
+		u.screen.ShowCursor(u.ex, editorTop+u.ey)
+	} else {
+		u.screen.HideCursor()
+	}
+
+	u.drawText(0, h-1, w, tcell.StyleDefault.Dim(true), u.status)
+}
+
+func (u *tui) drawText(x, y, maxWidth int, style tcell.Style, text string) {
+	col := x
+	for _, r := range text {
+		if col >= maxWidth {
+			break
+		}
+		u.screen.SetContent(col, y, r, nil, style)
+		col++
+	}
+}
+
+// drawHighlighted renders text with the runes at positions (as returned by
+// fuzzyScore) bolded, mirroring the Fyne picker's matched-rune highlighting.
+func (u *tui) drawHighlighted(x, y, maxWidth int, base tcell.Style, text string, positions []int) {
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+	col := x
+	for i, r := range []rune(text) {
+		if col >= maxWidth {
+			break
+		}
+		style := base
+		if matched[i] {
+			style = style.Bold(true)
+		}
+		u.screen.SetContent(col, y, r, nil, style)
+		col++
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import "os"
+
+// shouldRunTUI decides whether to start the headless tcell frontend instead
+// of the Fyne GUI: an explicit -tui flag always wins, and when
+// checkDisplayEnv is true it also kicks in when there's no display and
+// stdout looks like a terminal.
+func shouldRunTUI(tuiFlag bool, checkDisplayEnv bool) bool {
+	if tuiFlag {
+		return true
+	}
+	if !checkDisplayEnv {
+		return false
+	}
+	if os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
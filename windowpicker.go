@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// windowPickerEntry is one row a fuzzyWindowPicker can show: a display
+// label and an opaque key the caller uses to look up the OS window handle.
+type windowPickerEntry struct {
+	Label string
+	Key   string
+}
+
+// fuzzyWindowPicker replaces the flat alphabetical widget.Select with a
+// search box that narrows and re-ranks the list via fuzzyFilter as the
+// user types. "None (use last active)" is always row zero, unaffected by
+// the query.
+type fuzzyWindowPicker struct {
+	Search *widget.Entry
+	List   *widget.List
+
+	entries  []windowPickerEntry
+	filtered []fuzzyResult
+	selected string // Key of the selected entry, "" for "use last active"
+
+	onSelect func(key string)
+}
+
+func newFuzzyWindowPicker(onSelect func(key string)) *fuzzyWindowPicker {
+	p := &fuzzyWindowPicker{onSelect: onSelect}
+
+	p.Search = widget.NewEntry()
+	p.Search.SetPlaceHolder("Filter windows…")
+
+	p.List = widget.NewList(
+		func() int { return len(p.filtered) + 1 },
+		func() fyne.CanvasObject { return widget.NewRichText() },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			rt := obj.(*widget.RichText)
+			if id == 0 {
+				rt.Segments = []widget.RichTextSegment{&widget.TextSegment{
+					Text:  "None (use last active)",
+					Style: widget.RichTextStyle{TextStyle: fyne.TextStyle{Italic: true}},
+				}}
+				rt.Refresh()
+				return
+			}
+			res := p.filtered[id-1]
+			entry := p.entries[res.Index]
+			rt.Segments = richTextSegments(entry.Label, res.Positions)
+			rt.Refresh()
+		},
+	)
+
+	p.Search.OnChanged = func(q string) { p.refresh(q) }
+	p.List.OnSelected = func(id widget.ListItemID) {
+		if id == 0 {
+			p.selected = ""
+		} else {
+			p.selected = p.entries[p.filtered[id-1].Index].Key
+		}
+		if p.onSelect != nil {
+			p.onSelect(p.selected)
+		}
+	}
+
+	p.refresh("")
+	return p
+}
+
+// SetEntries replaces the candidate windows and re-applies the current
+// filter text.
+func (p *fuzzyWindowPicker) SetEntries(entries []windowPickerEntry) {
+	p.entries = entries
+	p.refresh(p.Search.Text)
+}
+
+// Clear resets the selection to "use last active" without touching the
+// filter text.
+func (p *fuzzyWindowPicker) Clear() {
+	p.selected = ""
+	p.List.UnselectAll()
+}
+
+// Selected returns the Key of the chosen window, or "" for "use last
+// active".
+func (p *fuzzyWindowPicker) Selected() string { return p.selected }
+
+func (p *fuzzyWindowPicker) refresh(query string) {
+	labels := make([]string, len(p.entries))
+	for i, e := range p.entries {
+		labels[i] = e.Label
+	}
+	p.filtered = fuzzyFilter(query, labels)
+	p.List.Refresh()
+}
+
+// richTextSegments renders label as RichTextSegments with the runes at
+// positions (as returned by fuzzyScore) bolded, coalescing consecutive
+// same-style runs into a single segment.
+func richTextSegments(label string, positions []int) []widget.RichTextSegment {
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var segs []widget.RichTextSegment
+	var buf []rune
+	bold := false
+	flush := func() {
+		if len(buf) == 0 {
+			return
+		}
+		segs = append(segs, &widget.TextSegment{
+			Text:  string(buf),
+			Style: widget.RichTextStyle{TextStyle: fyne.TextStyle{Bold: bold}},
+		})
+		buf = buf[:0]
+	}
+	for i, r := range []rune(label) {
+		isBold := matched[i]
+		if isBold != bold {
+			flush()
+			bold = isBold
+		}
+		buf = append(buf, r)
+	}
+	flush()
+	return segs
+}
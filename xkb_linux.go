@@ -0,0 +1,459 @@
+//go:build linux
+
+package main
+
+/*
+#cgo pkg-config: xkbcommon
+#include <stdlib.h>
+#include <xkbcommon/xkbcommon.h>
+
+static struct xkb_rule_names make_rule_names(const char *rules, const char *model,
+                                              const char *layout, const char *variant,
+                                              const char *options) {
+	struct xkb_rule_names n;
+	n.rules = rules;
+	n.model = model;
+	n.layout = layout;
+	n.variant = variant;
+	n.options = options;
+	return n;
+}
+*/
+import "C"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"unsafe"
+
+	"github.com/bendahl/uinput"
+)
+
+// Modifier bits used in keySpot.mods; these are the only levels goclip's
+// layouts realistically use (plain, Shift, AltGr, Shift+AltGr).
+const (
+	modShift = 1 << 0
+	modAltGr = 1 << 1
+)
+
+// keySpot is where a rune lives on the active keymap: the keycode to tap and
+// which modifiers must be held to reach the level that produces it.
+type keySpot struct {
+	keycode C.xkb_keycode_t
+	mods    int
+}
+
+// xkbTranslator owns an xkb_context/xkb_keymap for the current layout plus
+// two reverse indexes: rune to keySpot for direct lookups, and keysym to
+// keySpot for the compose fallback's dead-key/base-letter lookups.
+type xkbTranslator struct {
+	mu sync.Mutex
+
+	ctx    *C.struct_xkb_context
+	keymap *C.struct_xkb_keymap
+	state  *C.struct_xkb_state
+
+	shiftKeycode C.xkb_keycode_t
+	altGrKeycode C.xkb_keycode_t
+	shiftMask    C.xkb_mod_mask_t
+	altGrMask    C.xkb_mod_mask_t
+
+	index       map[rune]keySpot
+	keysymIndex map[C.xkb_keysym_t]keySpot
+}
+
+// rmlvo is the Rules/Model/Layout/Variant/Options tuple libxkbcommon needs to
+// build a keymap for the user's configured layout.
+type rmlvo struct {
+	rules, model, layout, variant, options string
+}
+
+// rmlvoFromEnv reads XKB_DEFAULT_* env vars. When XKB_DEFAULT_LAYOUT is
+// unset, it takes the layout and any other unset field from
+// /etc/default/keyboard (Debian/Ubuntu's console-setup file); when it is
+// set, that file is not read.
+func rmlvoFromEnv() rmlvo {
+	r := rmlvo{
+		rules:   os.Getenv("XKB_DEFAULT_RULES"),
+		model:   os.Getenv("XKB_DEFAULT_MODEL"),
+		layout:  os.Getenv("XKB_DEFAULT_LAYOUT"),
+		variant: os.Getenv("XKB_DEFAULT_VARIANT"),
+		options: os.Getenv("XKB_DEFAULT_OPTIONS"),
+	}
+	if r.layout == "" {
+		if fromFile, ok := readEtcDefaultKeyboard(); ok {
+			if r.rules == "" {
+				r.rules = fromFile.rules
+			}
+			if r.model == "" {
+				r.model = fromFile.model
+			}
+			r.layout = fromFile.layout
+			if r.variant == "" {
+				r.variant = fromFile.variant
+			}
+			if r.options == "" {
+				r.options = fromFile.options
+			}
+		}
+	}
+	return r
+}
+
+var etcKeyboardVar = regexp.MustCompile(`^\s*(XKBMODEL|XKBLAYOUT|XKBVARIANT|XKBOPTIONS)\s*=\s*"?([^"\n]*)"?\s*$`)
+
+func readEtcDefaultKeyboard() (rmlvo, bool) {
+	f, err := os.Open("/etc/default/keyboard")
+	if err != nil {
+		return rmlvo{}, false
+	}
+	defer f.Close()
+
+	var r rmlvo
+	found := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		m := etcKeyboardVar.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		found = true
+		switch m[1] {
+		case "XKBMODEL":
+			r.model = m[2]
+		case "XKBLAYOUT":
+			r.layout = m[2]
+		case "XKBVARIANT":
+			r.variant = m[2]
+		case "XKBOPTIONS":
+			r.options = m[2]
+		}
+	}
+	return r, found
+}
+
+// newXKBTranslator builds an xkb_context and initial keymap for the
+// environment's layout.
+func newXKBTranslator() (*xkbTranslator, error) {
+	ctx := C.xkb_context_new(C.XKB_CONTEXT_NO_FLAGS)
+	if ctx == nil {
+		return nil, fmt.Errorf("xkb: failed to create context")
+	}
+
+	t := &xkbTranslator{ctx: ctx}
+	if err := t.loadKeymap(rmlvoFromEnv()); err != nil {
+		C.xkb_context_unref(ctx)
+		return nil, err
+	}
+	return t, nil
+}
+
+// loadKeymap compiles a keymap from RMLVO names and swaps it in, rebuilding
+// the reverse index and locating the Shift/AltGr keycodes. This is the only
+// keymap source goclip builds today; there's no Wayland wl_keyboard.keymap
+// listener yet.
+func (t *xkbTranslator) loadKeymap(names rmlvo) error {
+	cRules := C.CString(names.rules)
+	cModel := C.CString(names.model)
+	cLayout := C.CString(names.layout)
+	cVariant := C.CString(names.variant)
+	cOptions := C.CString(names.options)
+	defer C.free(unsafe.Pointer(cRules))
+	defer C.free(unsafe.Pointer(cModel))
+	defer C.free(unsafe.Pointer(cLayout))
+	defer C.free(unsafe.Pointer(cVariant))
+	defer C.free(unsafe.Pointer(cOptions))
+
+	cNames := C.make_rule_names(cRules, cModel, cLayout, cVariant, cOptions)
+	keymap := C.xkb_keymap_new_from_names(t.ctx, &cNames, C.XKB_KEYMAP_COMPILE_NO_FLAGS)
+	if keymap == nil {
+		return fmt.Errorf("xkb: failed to build keymap for layout %q", names.layout)
+	}
+	return t.setKeymap(keymap)
+}
+
+func (t *xkbTranslator) setKeymap(keymap *C.struct_xkb_keymap) error {
+	state := C.xkb_state_new(keymap)
+	if state == nil {
+		C.xkb_keymap_unref(keymap)
+		return fmt.Errorf("xkb: failed to create state")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != nil {
+		C.xkb_state_unref(t.state)
+	}
+	if t.keymap != nil {
+		C.xkb_keymap_unref(t.keymap)
+	}
+	t.keymap = keymap
+	t.state = state
+	t.shiftKeycode = t.findKeycodeForKeysym(C.XKB_KEY_Shift_L)
+	t.altGrKeycode = t.findKeycodeForKeysym(C.XKB_KEY_ISO_Level3_Shift)
+	if t.altGrKeycode == 0 {
+		t.altGrKeycode = t.findKeycodeForKeysym(C.XKB_KEY_Mode_switch)
+	}
+	t.shiftMask = t.modMaskForName("Shift")
+	t.altGrMask = t.modMaskForName("Mod5")
+	if t.altGrMask == 0 {
+		t.altGrMask = t.modMaskForName("LevelThree")
+	}
+	t.index = t.buildReverseIndex()
+	t.keysymIndex = t.buildKeysymIndex()
+	return nil
+}
+
+// findKeycodeForKeysym scans level 0 of every key for a keysym, used to
+// locate the physical Shift/AltGr keys on the active keymap.
+func (t *xkbTranslator) findKeycodeForKeysym(sym C.xkb_keysym_t) C.xkb_keycode_t {
+	min := C.xkb_keymap_min_keycode(t.keymap)
+	max := C.xkb_keymap_max_keycode(t.keymap)
+	for kc := min; kc <= max; kc++ {
+		var syms *C.xkb_keysym_t
+		n := C.xkb_keymap_key_get_syms_by_level(t.keymap, kc, 0, 0, &syms)
+		for i := C.int(0); i < n; i++ {
+			s := *(*C.xkb_keysym_t)(unsafe.Pointer(uintptr(unsafe.Pointer(syms)) + uintptr(i)*unsafe.Sizeof(*syms)))
+			if s == sym {
+				return kc
+			}
+		}
+	}
+	return 0
+}
+
+// buildReverseIndex walks every keycode x level on layout 0 of the keymap,
+// recording the keycode/modifier pair that produces each codepoint. Keys
+// whose UTF-32 is 0 (function keys, dead keys, etc.) are skipped.
+func (t *xkbTranslator) buildReverseIndex() map[rune]keySpot {
+	idx := make(map[rune]keySpot)
+	if t.keymap == nil {
+		return idx
+	}
+
+	min := C.xkb_keymap_min_keycode(t.keymap)
+	max := C.xkb_keymap_max_keycode(t.keymap)
+
+	for kc := min; kc <= max; kc++ {
+		nLevels := C.xkb_keymap_num_levels_for_key(t.keymap, kc, 0)
+		for level := C.xkb_level_index_t(0); level < nLevels; level++ {
+			var maskList [4]C.xkb_mod_mask_t
+			n := C.xkb_keymap_key_get_mods_for_level(t.keymap, kc, 0, level, &maskList[0], C.size_t(len(maskList)))
+			if n == 0 {
+				continue
+			}
+			mask := maskList[0]
+
+			C.xkb_state_update_mask(t.state, mask, 0, 0, 0, 0, 0)
+			cp := rune(C.xkb_state_key_get_utf32(t.state, kc))
+			if cp == 0 {
+				continue
+			}
+
+			spot := keySpot{keycode: kc}
+			if t.shiftMask != 0 && mask&t.shiftMask != 0 {
+				spot.mods |= modShift
+			}
+			if t.altGrMask != 0 && mask&t.altGrMask != 0 {
+				spot.mods |= modAltGr
+			}
+
+			// Prefer the lowest-level (least-modified) spot for a rune, in
+			// case more than one level produces the same codepoint.
+			if existing, ok := idx[cp]; !ok || level < t.levelOf(existing) {
+				idx[cp] = spot
+			}
+		}
+	}
+
+	// Reset state to neutral so later state-dependent queries aren't left
+	// mid-modifier from the scan above.
+	C.xkb_state_update_mask(t.state, 0, 0, 0, 0, 0, 0)
+	return idx
+}
+
+// buildKeysymIndex is like buildReverseIndex but keyed by keysym instead of
+// codepoint, so dead keys (no standalone UTF-32 codepoint) are included;
+// the compose fallback uses it to find dead-key + base-letter keycodes.
+func (t *xkbTranslator) buildKeysymIndex() map[C.xkb_keysym_t]keySpot {
+	idx := make(map[C.xkb_keysym_t]keySpot)
+	if t.keymap == nil {
+		return idx
+	}
+
+	min := C.xkb_keymap_min_keycode(t.keymap)
+	max := C.xkb_keymap_max_keycode(t.keymap)
+
+	for kc := min; kc <= max; kc++ {
+		nLevels := C.xkb_keymap_num_levels_for_key(t.keymap, kc, 0)
+		for level := C.xkb_level_index_t(0); level < nLevels; level++ {
+			var syms *C.xkb_keysym_t
+			n := C.xkb_keymap_key_get_syms_by_level(t.keymap, kc, 0, level, &syms)
+			if n != 1 {
+				continue
+			}
+			sym := *(*C.xkb_keysym_t)(unsafe.Pointer(syms))
+
+			var maskList [4]C.xkb_mod_mask_t
+			mn := C.xkb_keymap_key_get_mods_for_level(t.keymap, kc, 0, level, &maskList[0], C.size_t(len(maskList)))
+			if mn == 0 {
+				continue
+			}
+			mask := maskList[0]
+
+			spot := keySpot{keycode: kc}
+			if t.shiftMask != 0 && mask&t.shiftMask != 0 {
+				spot.mods |= modShift
+			}
+			if t.altGrMask != 0 && mask&t.altGrMask != 0 {
+				spot.mods |= modAltGr
+			}
+
+			if existing, ok := idx[sym]; !ok || level < t.levelOf(existing) {
+				idx[sym] = spot
+			}
+		}
+	}
+	return idx
+}
+
+// modMaskForName resolves a modifier name (e.g. "Shift", "Mod5") to its
+// single-bit mask.
+func (t *xkbTranslator) modMaskForName(name string) C.xkb_mod_mask_t {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	idx := C.xkb_keymap_mod_get_index(t.keymap, cName)
+	if idx == C.XKB_MOD_INVALID {
+		return 0
+	}
+	return 1 << uint(idx)
+}
+
+// levelOf infers a keySpot's shift level from which modifiers are held.
+func (t *xkbTranslator) levelOf(spot keySpot) C.xkb_level_index_t {
+	switch spot.mods {
+	case 0:
+		return 0
+	case modShift:
+		return 1
+	case modAltGr:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// lookup returns the keySpot for a rune in the direct reverse index.
+func (t *xkbTranslator) lookup(r rune) (keySpot, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spot, ok := t.index[r]
+	return spot, ok
+}
+
+// shiftUinputCode and altGrUinputCode convert the physical Shift/AltGr
+// keycodes discovered on the active keymap to uinput evdev codes, falling
+// back to the standard left-Shift/right-Alt position if none was found.
+func (t *xkbTranslator) shiftUinputCode() int {
+	if t.shiftKeycode != 0 {
+		return int(t.shiftKeycode) - 8
+	}
+	return uinput.KeyLeftshift
+}
+
+func (t *xkbTranslator) altGrUinputCode() int {
+	if t.altGrKeycode != 0 {
+		return int(t.altGrKeycode) - 8
+	}
+	return uinput.KeyRightalt
+}
+
+func (t *xkbTranslator) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state != nil {
+		C.xkb_state_unref(t.state)
+	}
+	if t.keymap != nil {
+		C.xkb_keymap_unref(t.keymap)
+	}
+	if t.ctx != nil {
+		C.xkb_context_unref(t.ctx)
+	}
+}
+
+// keysymForASCII returns r's keysym when r is a plain ASCII base letter,
+// the only case goclip's compose fallback composes against today.
+func keysymForASCII(r rune) (C.xkb_keysym_t, bool) {
+	if r > unicode_MaxASCII {
+		return 0, false
+	}
+	return C.xkb_keysym_t(r), true
+}
+
+const unicode_MaxASCII = 0x7F
+
+// deadKeyFor maps a handful of common combining diacritics to the keysym of
+// the dead key that produces them. Scoped fallback, not a walk of the
+// locale's xkb_compose_table: libxkbcommon's compose API has no reverse
+// lookup from rune to sequence, so it needs a candidate table like this one
+// regardless; anything outside it falls through to the Unicode fallback.
+var deadKeyFor = map[rune]C.xkb_keysym_t{
+	'̀': C.XKB_KEY_dead_grave,
+	'́': C.XKB_KEY_dead_acute,
+	'̂': C.XKB_KEY_dead_circumflex,
+	'̃': C.XKB_KEY_dead_tilde,
+	'̈': C.XKB_KEY_dead_diaeresis,
+	'̧': C.XKB_KEY_dead_cedilla,
+	'̊': C.XKB_KEY_dead_abovering,
+}
+
+// decomposeAccented splits a precomposed lowercase Latin-1 letter from a
+// fixed table into (base rune, combining diacritic) for use with deadKeyFor;
+// uppercase and any other letters report ok=false.
+func decomposeAccented(r rune) (base rune, diacritic rune, ok bool) {
+	norm := map[rune][2]rune{
+		'á': {'a', '́'}, 'é': {'e', '́'}, 'í': {'i', '́'}, 'ó': {'o', '́'}, 'ú': {'u', '́'},
+		'à': {'a', '̀'}, 'è': {'e', '̀'}, 'ì': {'i', '̀'}, 'ò': {'o', '̀'}, 'ù': {'u', '̀'},
+		'â': {'a', '̂'}, 'ê': {'e', '̂'}, 'î': {'i', '̂'}, 'ô': {'o', '̂'}, 'û': {'u', '̂'},
+		'ã': {'a', '̃'}, 'õ': {'o', '̃'}, 'ñ': {'n', '̃'},
+		'ä': {'a', '̈'}, 'ë': {'e', '̈'}, 'ï': {'i', '̈'}, 'ö': {'o', '̈'}, 'ü': {'u', '̈'},
+		'ç': {'c', '̧'}, 'å': {'a', '̊'},
+	}
+	pair, ok := norm[r]
+	if !ok {
+		return 0, 0, false
+	}
+	return pair[0], pair[1], true
+}
+
+// typeRuneViaCompose finds the dead-key + base-letter keySpots a real
+// keyboard would press to produce r, relying on the receiving application's
+// own compose handling to turn the two keypresses into r.
+func (t *xkbTranslator) typeRuneViaCompose(r rune) ([]keySpot, bool) {
+	base, diacritic, ok := decomposeAccented(r)
+	if !ok {
+		return nil, false
+	}
+	deadSym, ok := deadKeyFor[diacritic]
+	if !ok {
+		return nil, false
+	}
+	baseSym, ok := keysymForASCII(base)
+	if !ok {
+		return nil, false
+	}
+
+	t.mu.Lock()
+	deadSpot, deadOK := t.keysymIndex[deadSym]
+	baseSpot, baseOK := t.keysymIndex[baseSym]
+	t.mu.Unlock()
+	if !deadOK || !baseOK {
+		return nil, false
+	}
+	return []keySpot{deadSpot, baseSpot}, true
+}